@@ -0,0 +1,44 @@
+// Package tencentdoc 提供基于 util 通用 HTTP helper 封装的腾讯文档 Open API 服务层。
+package tencentdoc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/chinahtl/tencent-doc-sdk/util"
+)
+
+const importDocumentURL = "https://docs.qq.com/openapi/drive/v2/import"
+
+// Service 封装访问腾讯文档 Open API 所需的公共配置。
+type Service struct {
+	httpClient *http.Client
+	token      string
+}
+
+// NewService 创建一个 Service，httpClient 为 nil 时使用 http.DefaultClient。
+func NewService(httpClient *http.Client, token string) *Service {
+	return &Service{httpClient: httpClient, token: token}
+}
+
+// ImportResult 是导入文档接口的响应结果。
+type ImportResult struct {
+	FileID string `json:"fileID"`
+	URL    string `json:"url"`
+}
+
+// ImportDocument 将本地文件（如 .docx/.xlsx）上传导入到腾讯文档。
+// fileType 对应腾讯文档要求的目标类型（如 "docx"、"xlsx"），progress 为可选的上传进度回调。
+func (s *Service) ImportDocument(ctx context.Context, file util.MultipartFile, fileType string, progress util.ProgressFunc) (*ImportResult, error) {
+	var result ImportResult
+	err := util.NewClient(s.httpClient).
+		Post(importDocumentURL).
+		SetAuthToken(s.token).
+		SetMultipart(map[string]string{"type": fileType}, map[string]util.MultipartFile{"file": file}, progress).
+		Do(ctx, &result)
+	if err != nil {
+		return nil, fmt.Errorf("import document failed: %w", err)
+	}
+	return &result, nil
+}
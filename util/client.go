@@ -0,0 +1,252 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// RequestInterceptor 在请求发出前对其进行处理，可用于签名、日志、埋点等场景。
+type RequestInterceptor func(*http.Request) error
+
+// ResponseInterceptor 在响应返回后、解析之前对其进行处理。
+type ResponseInterceptor func(*http.Response) error
+
+var (
+	globalInterceptorMu        sync.RWMutex
+	globalRequestInterceptors  []RequestInterceptor
+	globalResponseInterceptors []ResponseInterceptor
+)
+
+// RegisterRequestInterceptor 注册一个全局请求拦截器，对所有 Client 发出的请求生效。
+func RegisterRequestInterceptor(interceptor RequestInterceptor) {
+	globalInterceptorMu.Lock()
+	defer globalInterceptorMu.Unlock()
+	globalRequestInterceptors = append(globalRequestInterceptors, interceptor)
+}
+
+// RegisterResponseInterceptor 注册一个全局响应拦截器，对所有 Client 收到的响应生效。
+func RegisterResponseInterceptor(interceptor ResponseInterceptor) {
+	globalInterceptorMu.Lock()
+	defer globalInterceptorMu.Unlock()
+	globalResponseInterceptors = append(globalResponseInterceptors, interceptor)
+}
+
+// snapshotRequestInterceptors 返回全局请求拦截器的快照，避免遍历时与 Register 并发写入竞态。
+func snapshotRequestInterceptors() []RequestInterceptor {
+	globalInterceptorMu.RLock()
+	defer globalInterceptorMu.RUnlock()
+	return append([]RequestInterceptor(nil), globalRequestInterceptors...)
+}
+
+// snapshotResponseInterceptors 返回全局响应拦截器的快照，避免遍历时与 Register 并发写入竞态。
+func snapshotResponseInterceptors() []ResponseInterceptor {
+	globalInterceptorMu.RLock()
+	defer globalInterceptorMu.RUnlock()
+	return append([]ResponseInterceptor(nil), globalResponseInterceptors...)
+}
+
+// Client 是链式 HTTP 请求构造器，封装了鉴权、表单/JSON 编码等通用逻辑，
+// 取代此前散落在各个 PostXxx/GetXxx 函数中的重复代码。
+// 一个 Client 实例描述一次请求，调用 Do 发起请求并解析响应。
+type Client struct {
+	httpClient *http.Client
+
+	method    string
+	rawURL    string
+	query     url.Values
+	headers   http.Header
+	form      url.Values
+	jsonBody  interface{}
+	multipart *multipartBody
+	token     string
+
+	requestInterceptors  []RequestInterceptor
+	responseInterceptors []ResponseInterceptor
+
+	retryPolicy  *RetryPolicy
+	errorDecoder ErrorDecoder
+
+	debugLogger Logger
+	debugOpts   DebugOptions
+	requestID   string
+}
+
+// NewClient 创建一个新的链式请求构造器，httpClient 为 nil 时使用 http.DefaultClient。
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		httpClient: httpClient,
+		headers:    make(http.Header),
+	}
+}
+
+// Get 指定本次请求方法为 GET，并设置请求地址。
+func (c *Client) Get(rawURL string) *Client {
+	c.method = http.MethodGet
+	c.rawURL = rawURL
+	return c
+}
+
+// Post 指定本次请求方法为 POST，并设置请求地址。
+func (c *Client) Post(rawURL string) *Client {
+	c.method = http.MethodPost
+	c.rawURL = rawURL
+	return c
+}
+
+// SetQuery 设置 URL 查询参数。
+func (c *Client) SetQuery(query url.Values) *Client {
+	c.query = query
+	return c
+}
+
+// SetHeader 设置单个请求头，可链式多次调用。
+func (c *Client) SetHeader(key, value string) *Client {
+	c.headers.Set(key, value)
+	return c
+}
+
+// SetHeaders 批量设置请求头。
+func (c *Client) SetHeaders(headers map[string]string) *Client {
+	for k, v := range headers {
+		c.headers.Set(k, v)
+	}
+	return c
+}
+
+// SetAuthToken 设置 Bearer Token，等价于 SetHeader("Authorization", "Bearer "+token)。
+func (c *Client) SetAuthToken(token string) *Client {
+	c.token = token
+	return c
+}
+
+// SetForm 设置 application/x-www-form-urlencoded 请求体。
+func (c *Client) SetForm(form url.Values) *Client {
+	c.form = form
+	return c
+}
+
+// SetJSON 设置 application/json 请求体。
+func (c *Client) SetJSON(body interface{}) *Client {
+	c.jsonBody = body
+	return c
+}
+
+// Use 为当前 Client 注册一个请求拦截器，仅对该 Client 发出的请求生效。
+func (c *Client) Use(interceptor RequestInterceptor) *Client {
+	c.requestInterceptors = append(c.requestInterceptors, interceptor)
+	return c
+}
+
+// UseResponse 为当前 Client 注册一个响应拦截器，仅对该 Client 收到的响应生效。
+func (c *Client) UseResponse(interceptor ResponseInterceptor) *Client {
+	c.responseInterceptors = append(c.responseInterceptors, interceptor)
+	return c
+}
+
+// buildRequest 根据当前配置构造 *http.Request。
+func (c *Client) buildRequest(ctx context.Context) (*http.Request, error) {
+	if c.method == "" {
+		return nil, fmt.Errorf("util: request method not set, call Get/Post first")
+	}
+
+	u, err := url.Parse(c.rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+	if c.query != nil {
+		u.RawQuery = c.query.Encode()
+	}
+
+	var body io.Reader
+	contentType := ""
+	switch {
+	case c.multipart != nil:
+		pipeBody, mpContentType, err := buildMultipartBody(ctx, c.multipart)
+		if err != nil {
+			return nil, err
+		}
+		body = pipeBody
+		contentType = mpContentType
+	case c.jsonBody != nil:
+		raw, err := json.Marshal(c.jsonBody)
+		if err != nil {
+			return nil, fmt.Errorf("marshal json failed: %w", err)
+		}
+		body = bytes.NewBuffer(raw)
+		contentType = "application/json"
+	case c.form != nil:
+		body = bytes.NewBufferString(c.form.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, c.method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for k, values := range c.headers {
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return req, nil
+}
+
+// Do 发起请求并将响应解析到 result 中；result 为 nil 时响应体仍会交给 ErrorDecoder 检查错误，但不做解析。
+func (c *Client) Do(ctx context.Context, result interface{}) error {
+	buildAndIntercept := func() (*http.Request, error) {
+		req, err := c.buildRequest(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, interceptor := range snapshotRequestInterceptors() {
+			if err := interceptor(req); err != nil {
+				return nil, fmt.Errorf("request interceptor failed: %w", err)
+			}
+		}
+		for _, interceptor := range c.requestInterceptors {
+			if err := interceptor(req); err != nil {
+				return nil, fmt.Errorf("request interceptor failed: %w", err)
+			}
+		}
+		c.requestID = c.traceRequest(req)
+		return req, nil
+	}
+
+	idempotent := c.method != http.MethodPost
+	resp, err := c.doWithRetry(ctx, buildAndIntercept, idempotent)
+	if err != nil {
+		return fmt.Errorf("http request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	c.traceResponse(c.requestID, resp)
+
+	for _, interceptor := range snapshotResponseInterceptors() {
+		if err := interceptor(resp); err != nil {
+			return fmt.Errorf("response interceptor failed: %w", err)
+		}
+	}
+	for _, interceptor := range c.responseInterceptors {
+		if err := interceptor(resp); err != nil {
+			return fmt.Errorf("response interceptor failed: %w", err)
+		}
+	}
+
+	return c.decodeBody(resp, result)
+}
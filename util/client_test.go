@@ -0,0 +1,114 @@
+package util
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+func TestClient_GetDecodesJSONResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Custom") != "yes" {
+			t.Errorf("X-Custom header = %q, want %q", r.Header.Get("X-Custom"), "yes")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"value":"ok"}`))
+	}))
+	defer server.Close()
+
+	var result struct {
+		Value string `json:"value"`
+	}
+	err := NewClient(server.Client()).Get(server.URL).SetHeader("X-Custom", "yes").Do(context.Background(), &result)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if result.Value != "ok" {
+		t.Errorf("result.Value = %q, want %q", result.Value, "ok")
+	}
+}
+
+func TestClient_PostFormEncodesBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/x-www-form-urlencoded" {
+			t.Errorf("Content-Type = %q, want application/x-www-form-urlencoded", ct)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm failed: %v", err)
+		}
+		if got := r.Form.Get("name"); got != "tencent" {
+			t.Errorf("form[name] = %q, want %q", got, "tencent")
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	form := url.Values{"name": {"tencent"}}
+	err := NewClient(server.Client()).Post(server.URL).SetForm(form).Do(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+}
+
+func TestClient_RequestAndResponseInterceptorsRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Signed") != "1" {
+			t.Errorf("request interceptor did not run: X-Signed = %q", r.Header.Get("X-Signed"))
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var responseSeen bool
+	err := NewClient(server.Client()).
+		Get(server.URL).
+		Use(func(req *http.Request) error {
+			req.Header.Set("X-Signed", "1")
+			return nil
+		}).
+		UseResponse(func(resp *http.Response) error {
+			responseSeen = true
+			return nil
+		}).
+		Do(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if !responseSeen {
+		t.Error("response interceptor did not run")
+	}
+}
+
+func TestRegisterRequestInterceptor_ConcurrentWithDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			RegisterRequestInterceptor(func(req *http.Request) error { return nil })
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			if err := NewClient(server.Client()).Get(server.URL).Do(context.Background(), nil); err != nil {
+				t.Errorf("Do returned error: %v", err)
+			}
+		}
+	}()
+
+	wg.Wait()
+}
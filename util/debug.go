@@ -0,0 +1,144 @@
+package util
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+	"strings"
+)
+
+// Logger 是调试日志输出的最小接口，方便接入 zap/logrus 等第三方日志库。
+type Logger interface {
+	Debugf(format string, args ...interface{})
+}
+
+// LoggerFunc 是 Logger 的函数适配器。
+type LoggerFunc func(format string, args ...interface{})
+
+// Debugf 实现 Logger 接口。
+func (f LoggerFunc) Debugf(format string, args ...interface{}) { f(format, args...) }
+
+// DebugOptions 控制调试追踪的行为。
+type DebugOptions struct {
+	// DumpBody 控制是否打印请求/响应体，零值为 false，DefaultDebugOptions 中为 true；multipart/form-data 请求体始终被抑制。
+	DumpBody bool
+}
+
+// DefaultDebugOptions 返回默认的调试选项：打印请求体/响应体。
+func DefaultDebugOptions() DebugOptions {
+	return DebugOptions{DumpBody: true}
+}
+
+// redactedHeaders 是打印前会被打码的请求头。
+var redactedHeaders = []string{"Authorization"}
+
+// redactedFormKeys 是打印前会被打码的敏感键，既可能出现在表单/查询参数中（key=value），
+// 也可能出现在 JSON 请求体中（"key":"value"）。
+var redactedFormKeys = []string{"access_token", "refresh_token"}
+
+// redactionRule 为一个敏感键预编译好表单形态与 JSON 形态的匹配规则。
+type redactionRule struct {
+	key         string
+	formPattern *regexp.Regexp
+	jsonPattern *regexp.Regexp
+}
+
+var formRedactionRules = buildRedactionRules(redactedFormKeys)
+
+// buildRedactionRules 为每个敏感键构造 key=value 以及 "key":"value" 两种形态的正则。
+func buildRedactionRules(keys []string) []redactionRule {
+	rules := make([]redactionRule, 0, len(keys))
+	for _, key := range keys {
+		quoted := regexp.QuoteMeta(key)
+		rules = append(rules, redactionRule{
+			key:         key,
+			formPattern: regexp.MustCompile(`(?i)\b` + quoted + `=[^&\s"]*`),
+			jsonPattern: regexp.MustCompile(`(?i)"` + quoted + `"\s*:\s*"(?:[^"\\]|\\.)*"`),
+		})
+	}
+	return rules
+}
+
+// WithDebug 为当前 Client 开启调试模式：打印完整的请求/响应报文，并自动打码敏感信息。
+// logger 为 nil 时不会输出任何内容；opts 按原样生效，空值不会替换为 DefaultDebugOptions（即不打印请求/响应体）。
+func (c *Client) WithDebug(logger Logger, opts DebugOptions) *Client {
+	c.debugLogger = logger
+	c.debugOpts = opts
+	return c
+}
+
+// newRequestID 生成一个用于跨日志关联请求/响应的短 ID。
+func newRequestID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// traceRequest 在请求发出前打码敏感信息并打印完整报文，返回本次请求的关联 ID。
+func (c *Client) traceRequest(req *http.Request) string {
+	if req.Header.Get("X-Request-Id") == "" {
+		req.Header.Set("X-Request-Id", newRequestID())
+	}
+	requestID := req.Header.Get("X-Request-Id")
+
+	if c.debugLogger == nil {
+		return requestID
+	}
+
+	isMultipart := strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/form-data")
+	dumpBody := c.debugOpts.DumpBody && !isMultipart
+
+	dump, err := httputil.DumpRequestOut(req, dumpBody)
+	if err != nil {
+		c.debugLogger.Debugf("[%s] dump request failed: %v", requestID, err)
+		return requestID
+	}
+
+	c.debugLogger.Debugf("[%s] request:\n%s", requestID, redact(string(dump)))
+	return requestID
+}
+
+// traceResponse 在响应返回后打码敏感信息并打印完整报文。
+func (c *Client) traceResponse(requestID string, resp *http.Response) {
+	if c.debugLogger == nil {
+		return
+	}
+
+	isMultipart := strings.HasPrefix(resp.Request.Header.Get("Content-Type"), "multipart/form-data")
+	dumpBody := c.debugOpts.DumpBody && !isMultipart
+
+	dump, err := httputil.DumpResponse(resp, dumpBody)
+	if err != nil {
+		c.debugLogger.Debugf("[%s] dump response failed: %v", requestID, err)
+		return
+	}
+
+	c.debugLogger.Debugf("[%s] response:\n%s", requestID, redact(string(dump)))
+}
+
+// redact 打码敏感的 Authorization 请求头，以及 access_token/refresh_token
+// 在表单/查询参数（key=value）或 JSON 请求体（"key":"value"）中出现的取值。
+func redact(s string) string {
+	for _, header := range redactedHeaders {
+		s = redactHeaderLine(s, header)
+	}
+	for _, rule := range formRedactionRules {
+		s = rule.formPattern.ReplaceAllString(s, rule.key+"=[REDACTED]")
+		s = rule.jsonPattern.ReplaceAllString(s, `"`+rule.key+`":"[REDACTED]"`)
+	}
+	return s
+}
+
+func redactHeaderLine(s, header string) string {
+	lines := strings.Split(s, "\r\n")
+	for i, line := range lines {
+		if strings.HasPrefix(strings.ToLower(line), strings.ToLower(header)+":") {
+			lines[i] = header + ": [REDACTED]"
+		}
+	}
+	return strings.Join(lines, "\r\n")
+}
@@ -0,0 +1,73 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRedact_FormShapedTokens(t *testing.T) {
+	in := "POST /api HTTP/1.1\r\n\r\naccess_token=abc123&other=1"
+	got := redact(in)
+	if strings.Contains(got, "abc123") {
+		t.Errorf("redact() left form token visible: %s", got)
+	}
+	if !strings.Contains(got, "access_token=[REDACTED]") {
+		t.Errorf("redact() = %q, want access_token=[REDACTED]", got)
+	}
+	if !strings.Contains(got, "other=1") {
+		t.Errorf("redact() over-redacted unrelated field: %s", got)
+	}
+}
+
+func TestRedact_JSONShapedTokens(t *testing.T) {
+	in := `{"refresh_token":"super-secret","name":"tencent"}`
+	got := redact(in)
+	if strings.Contains(got, "super-secret") {
+		t.Errorf("redact() left JSON token visible: %s", got)
+	}
+	if !strings.Contains(got, `"refresh_token":"[REDACTED]"`) {
+		t.Errorf("redact() = %q, want refresh_token field redacted", got)
+	}
+	if !strings.Contains(got, `"name":"tencent"`) {
+		t.Errorf("redact() over-redacted unrelated field: %s", got)
+	}
+}
+
+func TestRedact_AuthorizationHeader(t *testing.T) {
+	in := "GET /api HTTP/1.1\r\nAuthorization: Bearer secret-token\r\nHost: example.com\r\n\r\n"
+	got := redact(in)
+	if strings.Contains(got, "secret-token") {
+		t.Errorf("redact() left Authorization header visible: %s", got)
+	}
+	if !strings.Contains(got, "Authorization: [REDACTED]") {
+		t.Errorf("redact() = %q, want Authorization header redacted", got)
+	}
+}
+
+func TestTraceRequest_RedactSensitiveData(t *testing.T) {
+	var logged []string
+	logger := LoggerFunc(func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	})
+
+	c := NewClient(nil)
+	c.WithDebug(logger, DefaultDebugOptions())
+	c.Post("http://example.invalid/api").SetAuthToken("super-secret-token")
+
+	req, err := c.buildRequest(context.Background())
+	if err != nil {
+		t.Fatalf("buildRequest returned error: %v", err)
+	}
+	c.traceRequest(req)
+
+	if len(logged) == 0 {
+		t.Fatal("expected traceRequest to log via debugLogger")
+	}
+	for _, entry := range logged {
+		if strings.Contains(entry, "super-secret-token") {
+			t.Errorf("traced request leaked auth token: %s", entry)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// APIError 表示一次失败的 API 调用，既覆盖 HTTP 层的非 200 响应，
+// 也覆盖腾讯文档在 HTTP 200 下通过 {"ret": <code>, "msg": "..."} 返回的业务错误。
+type APIError struct {
+	// StatusCode 是 HTTP 响应状态码。
+	StatusCode int
+	// Code 是腾讯文档返回的业务错误码（即响应体中的 ret 或 code 字段）。
+	Code int
+	// Msg 是腾讯文档返回的错误描述（即响应体中的 msg 字段）。
+	Msg string
+	// TraceID 取自响应头 X-Trace-Id/X-Request-Id，便于排查问题。
+	TraceID string
+	// Body 是响应体的原始内容片段，用于调试，超过 bodySnippetLimit 会被截断。
+	Body string
+}
+
+const bodySnippetLimit = 1024
+
+// Error 实现 error 接口。
+func (e *APIError) Error() string {
+	if e.Code != 0 {
+		return fmt.Sprintf("util: api error: status=%d code=%d msg=%q trace_id=%q", e.StatusCode, e.Code, e.Msg, e.TraceID)
+	}
+	return fmt.Sprintf("util: api error: status=%d msg=%q trace_id=%q", e.StatusCode, e.Msg, e.TraceID)
+}
+
+// 已知的腾讯文档业务错误码，供调用方与 APIError.Code 判等使用。
+const (
+	// CodeAuthExpired 表示鉴权凭证已过期，调用方应刷新 token 后重试。
+	CodeAuthExpired = 1002
+	// CodeRateLimited 表示触发了腾讯文档的接口频率限制。
+	CodeRateLimited = 1015
+	// CodeNotFound 表示请求的文档或资源不存在。
+	CodeNotFound = 1404
+)
+
+// IsAuthExpired 判断错误是否是鉴权过期。
+func (e *APIError) IsAuthExpired() bool { return e.Code == CodeAuthExpired }
+
+// IsRateLimited 判断错误是否是触发了限流。
+func (e *APIError) IsRateLimited() bool { return e.Code == CodeRateLimited }
+
+// IsNotFound 判断错误是否是资源不存在。
+func (e *APIError) IsNotFound() bool { return e.Code == CodeNotFound }
+
+// tencentEnvelope 是腾讯文档通用响应信封的最小字段集合，用于在解析前窥探是否携带业务错误。
+type tencentEnvelope struct {
+	Ret  *int   `json:"ret"`
+	Code *int   `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// ErrorDecoder 负责从一次 HTTP 响应中判断并构造业务错误，返回 nil 表示响应是成功的。
+// 调用方可以实现自定义 ErrorDecoder 以适配非标准的错误信封。
+type ErrorDecoder interface {
+	Decode(resp *http.Response, body []byte) *APIError
+}
+
+// ErrorDecoderFunc 是 ErrorDecoder 的函数适配器。
+type ErrorDecoderFunc func(resp *http.Response, body []byte) *APIError
+
+// Decode 实现 ErrorDecoder 接口。
+func (f ErrorDecoderFunc) Decode(resp *http.Response, body []byte) *APIError {
+	return f(resp, body)
+}
+
+// DefaultErrorDecoder 是开箱即用的错误解码器：非 200 状态码直接视为错误；
+// 200 状态码下会窥探响应体是否携带 ret/code 非零的业务错误信封。
+var DefaultErrorDecoder ErrorDecoder = ErrorDecoderFunc(defaultDecodeError)
+
+func defaultDecodeError(resp *http.Response, body []byte) *APIError {
+	traceID := resp.Header.Get("X-Trace-Id")
+	if traceID == "" {
+		traceID = resp.Header.Get("X-Request-Id")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			TraceID:    traceID,
+			Body:       snippet(body),
+		}
+	}
+
+	var envelope tencentEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		// 响应体不是信封格式（例如纯数组或非 JSON），视为正常响应交给调用方解析。
+		return nil
+	}
+
+	code := 0
+	switch {
+	case envelope.Ret != nil:
+		code = *envelope.Ret
+	case envelope.Code != nil:
+		code = *envelope.Code
+	}
+	if code == 0 {
+		return nil
+	}
+
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Code:       code,
+		Msg:        envelope.Msg,
+		TraceID:    traceID,
+		Body:       snippet(body),
+	}
+}
+
+// snippet 截断 body 用于错误信息展示，避免把超大响应塞进错误里。
+func snippet(body []byte) string {
+	if len(body) > bodySnippetLimit {
+		return string(body[:bodySnippetLimit])
+	}
+	return string(body)
+}
+
+// SetErrorDecoder 为当前 Client 指定自定义的 ErrorDecoder，未设置时使用 DefaultErrorDecoder。
+func (c *Client) SetErrorDecoder(decoder ErrorDecoder) *Client {
+	c.errorDecoder = decoder
+	return c
+}
+
+// decodeBody 读取响应体、交给 ErrorDecoder 判断是否出错，并在无错时把响应体解析到 result。
+func (c *Client) decodeBody(resp *http.Response, result interface{}) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response failed: %w", err)
+	}
+
+	decoder := c.errorDecoder
+	if decoder == nil {
+		decoder = DefaultErrorDecoder
+	}
+	if apiErr := decoder.Decode(resp, body); apiErr != nil {
+		return apiErr
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(result); err != nil {
+		return fmt.Errorf("decode response failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package util
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newTestResponse(statusCode int, headers map[string]string, body string) *http.Response {
+	h := make(http.Header)
+	for k, v := range headers {
+		h.Set(k, v)
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     h,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestDefaultDecodeError_NonOKStatus(t *testing.T) {
+	resp := newTestResponse(http.StatusBadGateway, map[string]string{"X-Trace-Id": "trace-1"}, "bad gateway")
+
+	apiErr := defaultDecodeError(resp, []byte("bad gateway"))
+	if apiErr == nil {
+		t.Fatal("expected non-nil APIError for non-200 status")
+	}
+	if apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, http.StatusBadGateway)
+	}
+	if apiErr.TraceID != "trace-1" {
+		t.Errorf("TraceID = %q, want %q", apiErr.TraceID, "trace-1")
+	}
+}
+
+func TestDefaultDecodeError_RetEnvelope(t *testing.T) {
+	body := `{"ret": 1002, "msg": "token expired"}`
+	resp := newTestResponse(http.StatusOK, nil, body)
+
+	apiErr := defaultDecodeError(resp, []byte(body))
+	if apiErr == nil {
+		t.Fatal("expected non-nil APIError for non-zero ret envelope")
+	}
+	if apiErr.Code != CodeAuthExpired {
+		t.Errorf("Code = %d, want %d", apiErr.Code, CodeAuthExpired)
+	}
+	if apiErr.Msg != "token expired" {
+		t.Errorf("Msg = %q, want %q", apiErr.Msg, "token expired")
+	}
+	if !apiErr.IsAuthExpired() {
+		t.Error("IsAuthExpired() = false, want true")
+	}
+}
+
+func TestDefaultDecodeError_CodeEnvelope(t *testing.T) {
+	body := `{"code": 1404, "msg": "not found"}`
+	resp := newTestResponse(http.StatusOK, nil, body)
+
+	apiErr := defaultDecodeError(resp, []byte(body))
+	if apiErr == nil {
+		t.Fatal("expected non-nil APIError for non-zero code envelope")
+	}
+	if !apiErr.IsNotFound() {
+		t.Error("IsNotFound() = false, want true")
+	}
+}
+
+func TestDefaultDecodeError_SuccessEnvelope(t *testing.T) {
+	body := `{"ret": 0, "data": {"fileID": "abc"}}`
+	resp := newTestResponse(http.StatusOK, nil, body)
+
+	if apiErr := defaultDecodeError(resp, []byte(body)); apiErr != nil {
+		t.Fatalf("expected nil APIError for ret=0, got %v", apiErr)
+	}
+}
+
+func TestDefaultDecodeError_NonEnvelopeBody(t *testing.T) {
+	body := `[1, 2, 3]`
+	resp := newTestResponse(http.StatusOK, nil, body)
+
+	if apiErr := defaultDecodeError(resp, []byte(body)); apiErr != nil {
+		t.Fatalf("expected nil APIError for non-envelope JSON body, got %v", apiErr)
+	}
+}
@@ -0,0 +1,149 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+)
+
+// MultipartFile 描述一个通过 multipart/form-data 上传的文件字段。
+type MultipartFile struct {
+	// Filename 是写入 multipart 头的文件名。
+	Filename string
+	// ContentType 是该文件部分的 Content-Type，留空时使用 mime/multipart 默认的 application/octet-stream。
+	ContentType string
+	// Reader 提供文件内容，PostMultipart 会边读边写，不会整体缓冲进内存。
+	Reader io.Reader
+}
+
+// ProgressFunc 在 multipart 请求体每写出一部分后被调用，written 为累计已写出的字节数。
+type ProgressFunc func(written int64)
+
+// multipartBody 保存构造 multipart/form-data 请求体所需的字段。
+type multipartBody struct {
+	fields   map[string]string
+	files    map[string]MultipartFile
+	progress ProgressFunc
+
+	// servedOnce 标记请求体是否已经被读取过一次，用于在重试时识别并重置/拒绝不可重放的文件来源。
+	servedOnce bool
+}
+
+// PostMultipart 发送 multipart/form-data 请求，用于文件上传场景（如导入 .docx/.xlsx 到腾讯文档）。
+// fields 是普通表单字段，files 是文件字段；progress 为可选的进度回调，传 nil 表示不关心进度。
+func PostMultipart(
+	ctx context.Context,
+	client *http.Client,
+	endpoint string,
+	fields map[string]string,
+	files map[string]MultipartFile,
+	headers map[string]string,
+	progress ProgressFunc,
+	result interface{},
+) error {
+	return NewClient(client).Post(endpoint).SetHeaders(headers).SetMultipart(fields, files, progress).Do(ctx, result)
+}
+
+// SetMultipart 将请求体设置为 multipart/form-data。设置后会覆盖此前通过 SetForm/SetJSON 设置的请求体。
+func (c *Client) SetMultipart(fields map[string]string, files map[string]MultipartFile, progress ProgressFunc) *Client {
+	c.multipart = &multipartBody{fields: fields, files: files, progress: progress}
+	return c
+}
+
+// countingWriter 统计累计写入的字节数，并在每次 Write 后回调 progress。
+type countingWriter struct {
+	w        io.Writer
+	written  int64
+	progress ProgressFunc
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.written += int64(n)
+	if c.progress != nil {
+		c.progress(c.written)
+	}
+	return n, err
+}
+
+// buildMultipartBody 通过管道将 multipart 请求体边写边发，避免把待上传文件整体读入内存。
+// 返回的 io.ReadCloser 在 ctx 被取消时会提前结束读取。
+//
+// 配合 WithRetry 重试时，同一个 body 会被多次调用：首次调用直接消费 file.Reader；
+// 若发生重试，非 io.Seeker 的 Reader 已被读空，为避免悄悄上传被截断的文件，
+// 这里要求文件必须是 io.Seeker 才允许重试，否则直接报错。
+func buildMultipartBody(ctx context.Context, body *multipartBody) (io.ReadCloser, string, error) {
+	if body.servedOnce {
+		for name, file := range body.files {
+			seeker, ok := file.Reader.(io.Seeker)
+			if !ok {
+				return nil, "", fmt.Errorf("util: retrying multipart upload requires file %q to be an io.Seeker", name)
+			}
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return nil, "", fmt.Errorf("util: reset multipart file %q for retry failed: %w", name, err)
+			}
+		}
+	}
+	body.servedOnce = true
+
+	pr, pw := io.Pipe()
+	cw := &countingWriter{w: pw, progress: body.progress}
+	mw := multipart.NewWriter(cw)
+	contentType := mw.FormDataContentType()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer pw.Close()
+
+		for name, value := range body.fields {
+			if err := mw.WriteField(name, value); err != nil {
+				pw.CloseWithError(fmt.Errorf("write multipart field %q failed: %w", name, err))
+				return
+			}
+		}
+
+		for name, file := range body.files {
+			var part io.Writer
+			var err error
+			if file.ContentType != "" {
+				part, err = mw.CreatePart(multipartFileHeader(name, file.Filename, file.ContentType))
+			} else {
+				part, err = mw.CreateFormFile(name, file.Filename)
+			}
+			if err != nil {
+				pw.CloseWithError(fmt.Errorf("create multipart file %q failed: %w", name, err))
+				return
+			}
+			if _, err := io.Copy(part, file.Reader); err != nil {
+				pw.CloseWithError(fmt.Errorf("write multipart file %q failed: %w", name, err))
+				return
+			}
+		}
+
+		if err := mw.Close(); err != nil {
+			pw.CloseWithError(fmt.Errorf("close multipart writer failed: %w", err))
+		}
+	}()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			pw.CloseWithError(ctx.Err())
+		case <-done:
+		}
+	}()
+
+	return pr, contentType, nil
+}
+
+// multipartFileHeader 构造携带自定义 Content-Type 的文件部分头，用于 CreatePart。
+func multipartFileHeader(fieldName, filename, contentType string) textproto.MIMEHeader {
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, fieldName, filename))
+	h.Set("Content-Type", contentType)
+	return h
+}
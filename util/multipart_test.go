@@ -0,0 +1,83 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestBuildMultipartBody_StreamsFieldsAndFiles(t *testing.T) {
+	body := &multipartBody{
+		fields: map[string]string{"title": "hello"},
+		files: map[string]MultipartFile{
+			"file": {Filename: "a.txt", Reader: strings.NewReader("file-content")},
+		},
+	}
+
+	pr, contentType, err := buildMultipartBody(context.Background(), body)
+	if err != nil {
+		t.Fatalf("buildMultipartBody returned error: %v", err)
+	}
+	if !strings.HasPrefix(contentType, "multipart/form-data; boundary=") {
+		t.Fatalf("contentType = %q, want multipart/form-data prefix", contentType)
+	}
+
+	got, err := io.ReadAll(pr)
+	if err != nil {
+		t.Fatalf("read body failed: %v", err)
+	}
+	if !strings.Contains(string(got), "hello") {
+		t.Errorf("body missing field value: %s", got)
+	}
+	if !strings.Contains(string(got), "file-content") {
+		t.Errorf("body missing file content: %s", got)
+	}
+}
+
+func TestBuildMultipartBody_RetryWithSeekableFileResets(t *testing.T) {
+	body := &multipartBody{
+		files: map[string]MultipartFile{
+			"file": {Filename: "a.txt", Reader: bytes.NewReader([]byte("payload"))},
+		},
+	}
+
+	pr1, _, err := buildMultipartBody(context.Background(), body)
+	if err != nil {
+		t.Fatalf("first buildMultipartBody returned error: %v", err)
+	}
+	io.ReadAll(pr1)
+
+	pr2, _, err := buildMultipartBody(context.Background(), body)
+	if err != nil {
+		t.Fatalf("retry buildMultipartBody returned error: %v", err)
+	}
+	got, err := io.ReadAll(pr2)
+	if err != nil {
+		t.Fatalf("read retried body failed: %v", err)
+	}
+	if !strings.Contains(string(got), "payload") {
+		t.Errorf("retried body missing file content, got: %s", got)
+	}
+}
+
+func TestBuildMultipartBody_RetryWithNonSeekableFileFails(t *testing.T) {
+	body := &multipartBody{
+		files: map[string]MultipartFile{
+			// io.NopCloser 包装后只暴露 Read/Close（以及 WriteTo），屏蔽了底层 strings.Reader 的 Seek 方法，
+			// 用来模拟真正不可重放的流式来源（如网络流）。
+			"file": {Filename: "a.txt", Reader: io.NopCloser(strings.NewReader("payload"))},
+		},
+	}
+
+	pr1, _, err := buildMultipartBody(context.Background(), body)
+	if err != nil {
+		t.Fatalf("first buildMultipartBody returned error: %v", err)
+	}
+	io.ReadAll(pr1)
+
+	if _, _, err := buildMultipartBody(context.Background(), body); err == nil {
+		t.Fatal("expected retry with non-seekable reader to fail, got nil error")
+	}
+}
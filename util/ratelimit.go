@@ -0,0 +1,117 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// 腾讯文档按接口族划分了不同的 QPS 上限，这里给出默认分组名以便调用方直接复用。
+const (
+	// GroupOpenAPI 对应通用的开放接口（文档元数据、权限管理等）。
+	GroupOpenAPI = "open-api"
+	// GroupFileOperation 对应文件操作类接口（创建、复制、导入等）。
+	GroupFileOperation = "file-operation"
+	// GroupAsyncTask 对应异步任务轮询接口（导出、转换进度查询等）。
+	GroupAsyncTask = "async-task"
+)
+
+// GroupResolver 根据一次请求判断它属于哪个限流分组。
+type GroupResolver func(*http.Request) string
+
+// DefaultGroupResolver 按 URL 路径的关键字把请求归类到内置分组，未命中时归入 GroupOpenAPI。
+func DefaultGroupResolver(req *http.Request) string {
+	path := req.URL.Path
+	switch {
+	case strings.Contains(path, "/task"):
+		return GroupAsyncTask
+	case strings.Contains(path, "/import"), strings.Contains(path, "/export"), strings.Contains(path, "/upload"):
+		return GroupFileOperation
+	default:
+		return GroupOpenAPI
+	}
+}
+
+// groupLimit 是单个分组的限流配置：令牌桶限速 + 最大并发数。
+type groupLimit struct {
+	limiter   *rate.Limiter
+	semaphore chan struct{}
+}
+
+var (
+	rateLimitMu     sync.Mutex
+	rateLimitGroups               = make(map[string]*groupLimit)
+	groupResolver   GroupResolver = DefaultGroupResolver
+)
+
+// WithRateLimit 为 group 配置令牌桶限速（rps、burst），对所有 Client 生效。
+// 可选的 maxConcurrent 额外限制该分组的最大同时在途请求数，不传或传 <= 0 表示不限制并发。
+func WithRateLimit(group string, rps float64, burst int, maxConcurrent ...int) {
+	rateLimitMu.Lock()
+	defer rateLimitMu.Unlock()
+
+	limit := &groupLimit{limiter: rate.NewLimiter(rate.Limit(rps), burst)}
+	if len(maxConcurrent) > 0 && maxConcurrent[0] > 0 {
+		limit.semaphore = make(chan struct{}, maxConcurrent[0])
+	}
+	rateLimitGroups[group] = limit
+}
+
+// SetGroupResolver 替换默认的分组识别逻辑，用于按 URL 路径以外的规则对请求分组。
+func SetGroupResolver(resolver GroupResolver) {
+	if resolver == nil {
+		resolver = DefaultGroupResolver
+	}
+	rateLimitMu.Lock()
+	groupResolver = resolver
+	rateLimitMu.Unlock()
+}
+
+// RateLimitWaitRecorder 接收每次限流等待的分组与耗时，可用于接入指标系统。
+type RateLimitWaitRecorder func(group string, waited time.Duration)
+
+// rateLimitWaitRecorder 是当前生效的等待耗时回调，默认不做任何事。
+var rateLimitWaitRecorder RateLimitWaitRecorder = func(string, time.Duration) {}
+
+// SetRateLimitWaitRecorder 设置全局的限流等待耗时回调，用于上报 wait-time 指标。
+func SetRateLimitWaitRecorder(recorder RateLimitWaitRecorder) {
+	if recorder == nil {
+		recorder = func(string, time.Duration) {}
+	}
+	rateLimitWaitRecorder = recorder
+}
+
+// waitForRateLimit 阻塞直到请求所属分组放行，并返回释放并发槽位的函数。
+// 未对应分组配置过 WithRateLimit 时直接放行，不做任何限制。
+func waitForRateLimit(ctx context.Context, req *http.Request) (func(), error) {
+	rateLimitMu.Lock()
+	resolver := groupResolver
+	group := resolver(req)
+	limit, ok := rateLimitGroups[group]
+	rateLimitMu.Unlock()
+	if !ok {
+		return func() {}, nil
+	}
+
+	start := time.Now()
+	if err := limit.limiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("util: rate limit wait for group %q failed: %w", group, err)
+	}
+	rateLimitWaitRecorder(group, time.Since(start))
+
+	if limit.semaphore == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case limit.semaphore <- struct{}{}:
+		return func() { <-limit.semaphore }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
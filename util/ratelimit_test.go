@@ -0,0 +1,79 @@
+package util
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimit_ThrottlesRequests(t *testing.T) {
+	const group = "test-throttle-group"
+	WithRateLimit(group, 5, 1)
+	defer delete(rateLimitGroups, group)
+
+	SetGroupResolver(func(*http.Request) string { return group })
+	defer SetGroupResolver(nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.invalid", nil)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		release, err := waitForRateLimit(context.Background(), req)
+		if err != nil {
+			t.Fatalf("waitForRateLimit returned error: %v", err)
+		}
+		release()
+	}
+	elapsed := time.Since(start)
+
+	// burst=1, rps=5 意味着第 2、3 次请求各自需要等待约 1/5 秒，总计应明显大于 0。
+	if elapsed < 300*time.Millisecond {
+		t.Errorf("elapsed = %v, want at least ~300ms given rps=5 burst=1", elapsed)
+	}
+}
+
+func TestWithRateLimit_CapsConcurrency(t *testing.T) {
+	const group = "test-concurrency-group"
+	WithRateLimit(group, 1000, 1000, 2)
+	defer delete(rateLimitGroups, group)
+
+	SetGroupResolver(func(*http.Request) string { return group })
+	defer SetGroupResolver(nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.invalid", nil)
+
+	var inFlight, maxInFlight int32
+	done := make(chan struct{})
+
+	for i := 0; i < 6; i++ {
+		go func() {
+			release, err := waitForRateLimit(context.Background(), req)
+			if err != nil {
+				t.Errorf("waitForRateLimit returned error: %v", err)
+				done <- struct{}{}
+				return
+			}
+			cur := atomic.AddInt32(&inFlight, 1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if cur <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, cur) {
+					break
+				}
+			}
+			time.Sleep(50 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			release()
+			done <- struct{}{}
+		}()
+	}
+
+	for i := 0; i < 6; i++ {
+		<-done
+	}
+
+	if maxInFlight > 2 {
+		t.Errorf("maxInFlight = %d, want <= 2", maxInFlight)
+	}
+}
@@ -0,0 +1,199 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy 描述请求失败后的退避重试策略。
+type RetryPolicy struct {
+	// MaxAttempts 是总尝试次数（含首次请求），<= 1 表示不重试。
+	MaxAttempts int
+	// BaseDelay 是第一次重试前的基础等待时间。
+	BaseDelay time.Duration
+	// MaxDelay 是单次等待的上限，超过后不再继续增长。
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy 是开箱即用的重试策略：最多尝试 3 次，基础延迟 200ms，上限 5s。
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+	}
+}
+
+// backoff 计算第 attempt（从 0 开始）次重试前的退避上限 delay（BaseDelay<<attempt，不超过 MaxDelay），
+// 并返回 [0, delay] 内均匀分布的随机等待时间（全抖动）。
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << attempt
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// WithRetry 为 Client 配置重试策略，使其在 429/502/503/504 及瞬时网络错误时自动退避重试；
+// 非幂等的 POST 请求只在连接建立阶段失败时重试。
+func (c *Client) WithRetry(policy RetryPolicy) *Client {
+	c.retryPolicy = &policy
+	return c
+}
+
+// retryableStatus 判断响应状态码是否值得重试。
+func retryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryableError 判断传输层错误是否是瞬时的、值得重试的。
+func retryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+	return false
+}
+
+// retryAfter 解析 Retry-After 响应头，返回服务端要求的等待时间，无法解析时返回 0。
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// doWithRetry 执行一次请求，并按 policy 在可重试的失败上退避重试。
+// idempotent 为 false（典型地是 POST）时，只有在连接阶段（尚未写出任何字节）失败才允许重试。
+func (c *Client) doWithRetry(ctx context.Context, req requestFactory, idempotent bool) (*http.Response, error) {
+	policy := c.retryPolicy
+	if policy == nil {
+		defaultPolicy := DefaultRetryPolicy()
+		policy = &defaultPolicy
+		policy.MaxAttempts = 1
+	}
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		httpReq, err := req()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.send(ctx, httpReq)
+		if err == nil && !retryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		canRetry := attempt < maxAttempts-1
+		if err != nil {
+			lastErr = err
+			if idempotent {
+				canRetry = canRetry && retryableError(err)
+			} else {
+				canRetry = canRetry && isConnectionError(err)
+			}
+		} else {
+			lastErr = nil
+			canRetry = canRetry && idempotent
+			if !canRetry {
+				return resp, nil
+			}
+			wait := retryAfter(resp)
+			resp.Body.Close()
+			if wait > 0 {
+				if waitErr := sleepCtx(ctx, wait); waitErr != nil {
+					return nil, waitErr
+				}
+				continue
+			}
+		}
+
+		if !canRetry {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return resp, nil
+		}
+
+		if waitErr := sleepCtx(ctx, policy.backoff(attempt)); waitErr != nil {
+			return nil, waitErr
+		}
+	}
+
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, errors.New("util: retry attempts exhausted")
+}
+
+// send 在请求所属分组的限流允许后发出一次请求；未配置任何 WithRateLimit 分组时直接放行。
+func (c *Client) send(ctx context.Context, httpReq *http.Request) (*http.Response, error) {
+	release, err := waitForRateLimit(ctx, httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return c.httpClient.Do(httpReq)
+}
+
+// requestFactory 每次重试都重新构造一次 *http.Request，因为请求体一旦被读取就无法复用。
+type requestFactory func() (*http.Request, error)
+
+// isConnectionError 粗略判断错误是否发生在连接建立阶段（尚未发送任何请求字节）。
+func isConnectionError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial"
+	}
+	return false
+}
+
+// sleepCtx 等待 d 时间，若 ctx 提前结束则返回 ctx.Err()。
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package util
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func newStatusResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestDoWithRetry_GETRetriesOnTransientStatus(t *testing.T) {
+	calls := 0
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return newStatusResponse(http.StatusServiceUnavailable, "unavailable"), nil
+		}
+		return newStatusResponse(http.StatusOK, "ok"), nil
+	})
+
+	c := NewClient(&http.Client{Transport: transport})
+	c.retryPolicy = &RetryPolicy{MaxAttempts: 2}
+
+	req := func() (*http.Request, error) {
+		return http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.invalid", nil)
+	}
+
+	resp, err := c.doWithRetry(context.Background(), req, true)
+	if err != nil {
+		t.Fatalf("doWithRetry returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestDoWithRetry_POSTDoesNotRetryOnTransientStatus(t *testing.T) {
+	calls := 0
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return newStatusResponse(http.StatusServiceUnavailable, "unavailable"), nil
+	})
+
+	c := NewClient(&http.Client{Transport: transport})
+	c.retryPolicy = &RetryPolicy{MaxAttempts: 3}
+
+	req := func() (*http.Request, error) {
+		return http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.invalid", nil)
+	}
+
+	resp, err := c.doWithRetry(context.Background(), req, false)
+	if err != nil {
+		t.Fatalf("doWithRetry returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (non-idempotent request must not retry a post-write transient status)", calls)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+}
+
+func TestDoWithRetry_ExhaustedRetriesReturnReadableBody(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return newStatusResponse(http.StatusServiceUnavailable, "unavailable"), nil
+	})
+
+	c := NewClient(&http.Client{Transport: transport})
+	c.retryPolicy = &RetryPolicy{MaxAttempts: 2}
+
+	req := func() (*http.Request, error) {
+		return http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.invalid", nil)
+	}
+
+	resp, err := c.doWithRetry(context.Background(), req, true)
+	if err != nil {
+		t.Fatalf("doWithRetry returned error: %v", err)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading exhausted response body failed: %v", err)
+	}
+	if string(body) != "unavailable" {
+		t.Errorf("body = %q, want %q", string(body), "unavailable")
+	}
+}
+
+func TestRetryableError(t *testing.T) {
+	if retryableError(nil) {
+		t.Error("retryableError(nil) = true, want false")
+	}
+	if !retryableError(io.EOF) {
+		t.Error("retryableError(io.EOF) = false, want true")
+	}
+}